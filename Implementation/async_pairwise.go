@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// |======================================|
+// |Async Pairwise Algorithm (gossip style)|
+// |======================================|
+// PairwiseAlgorithm face o trecere sincrona peste toate muchiile la fiecare
+// iteratie. AsyncPairwiseAlgorithm e varianta gossip: fiecare nod ruleaza in
+// propriul goroutine, alege la fiecare tact un vecin aleator, ii cere
+// derivata/k printr-un canal, si aplica update-ul local sub System.mut
+
+// asyncTickJitter e pauza dintre tacte; fara ea goroutine-urile ar rula
+// intr-o bucla stransa care satureaza CPU-ul fara a lasa loc de adevarat intretesut
+const asyncTickJitter = time.Millisecond
+
+// gossipRequest e cererea trimisa catre un vecin pentru derivata/k curente
+type gossipRequest struct {
+	reply chan gossipReply
+}
+
+// gossipReply contine derivata si factorul ki ale nodului care raspunde
+type gossipReply struct {
+	derivative float64
+	k          float64
+}
+
+// exchangeTracker tine, per muchie, ultimele "window" schimburi (in valoare
+// absoluta) si decide convergenta cand toate muchiile topologiei au fost deja
+// folosite si niciuna n-a mai schimbat peste epsilon in ultimele window tacte
+type exchangeTracker struct {
+	window     int
+	totalEdges int
+	history    map[Edge][]float64
+}
+
+func newExchangeTracker(window, totalEdges int) *exchangeTracker {
+	return &exchangeTracker{
+		window:     window,
+		totalEdges: totalEdges,
+		history:    make(map[Edge][]float64),
+	}
+}
+
+// record adauga schimbul curent in istoricul muchiei (i, j) si intoarce true
+// daca sistemul a convers (toate muchiile folosite, toate sub prag)
+func (t *exchangeTracker) record(i, j int, exchange, epsilon float64) bool {
+	e := canonicalEdge(i, j)
+
+	hist := append(t.history[e], math.Abs(exchange))
+	if len(hist) > t.window {
+		hist = hist[len(hist)-t.window:]
+	}
+	t.history[e] = hist
+
+	if len(t.history) < t.totalEdges {
+		return false
+	}
+	for _, h := range t.history {
+		if len(h) < t.window {
+			return false
+		}
+		for _, v := range h {
+			if v > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AsyncPairwiseAlgorithm ruleaza varianta gossip asincrona a PairwiseAlgorithm
+// pe topologia data. Fiecare nod are un goroutine "server" care raspunde la
+// cereri de gossip cu propria derivata/k, si un goroutine "client" care, la
+// fiecare tact, alege un vecin aleator, ii cere derivata/k printr-un canal si
+// aplica actualizarea -α·(ki·kj)/(ki+kj)·(di-dj), sub System.mut pentru a evita
+// scrieri concurente pe aceeasi alocare
+func AsyncPairwiseAlgorithm(s *System, topo Topology, alpha float64, maxTicks int, epsilon float64, window int) {
+	fmt.Println("\n" + strings.Repeat("=", 50))
+	fmt.Printf("Async Pairwise Algorithm (topologie: %s)\n", topo.Name())
+	fmt.Println(strings.Repeat("=", 50))
+
+	n := len(s.Nodes)
+	edges := topo.Edges()
+	adjacency := buildAdjacency(n, edges)
+
+	inboxes := make([]chan gossipRequest, n)
+	for i := range inboxes {
+		inboxes[i] = make(chan gossipRequest, n)
+	}
+
+	stopCh := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(stopCh) }) }
+
+	tracker := newExchangeTracker(window, len(edges))
+
+	var responders sync.WaitGroup
+	for i := 0; i < n; i++ {
+		responders.Add(1)
+		go func(nodeID int) {
+			defer responders.Done()
+			for {
+				select {
+				case req := <-inboxes[nodeID]:
+					s.mut.Lock()
+					d := s.ComputeFirstDerivative(nodeID)
+					k := s.Compute1onSecondDerivative(nodeID)
+					s.mut.Unlock()
+					req.reply <- gossipReply{derivative: d, k: k}
+				case <-stopCh:
+					return
+				}
+			}
+		}(i)
+	}
+
+	var clients sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if len(adjacency[i]) == 0 {
+			continue
+		}
+		clients.Add(1)
+		go func(nodeID int) {
+			defer clients.Done()
+			rng := rand.New(rand.NewSource(int64(nodeID) + 1))
+
+			for tick := 0; tick < maxTicks; tick++ {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+
+				neighbor := adjacency[nodeID][rng.Intn(len(adjacency[nodeID]))]
+				reply := make(chan gossipReply, 1)
+
+				select {
+				case inboxes[neighbor] <- gossipRequest{reply: reply}:
+				case <-stopCh:
+					return
+				}
+
+				var neighborInfo gossipReply
+				select {
+				case neighborInfo = <-reply:
+				case <-stopCh:
+					return
+				}
+
+				s.mut.Lock()
+				di := s.ComputeFirstDerivative(nodeID)
+				ki := s.Compute1onSecondDerivative(nodeID)
+				dj, kj := neighborInfo.derivative, neighborInfo.k
+
+				// Δxi = -α · (ki·kj)/(ki+kj) · (di - dj), aplicata simetric
+				exchange := -alpha * (ki * kj) / (ki + kj) * (di - dj)
+
+				// clamparea independenta a celor doua capete ar putea rupe
+				// Σxi = 1 daca unul dintre ele atinge marginea; trecem prin
+				// Normalize (ca toti ceilalti algoritmi) ca sa ramana o
+				// alocare valida dupa fiecare schimb, nu doar dupa cele doua
+				// noduri atinse
+				newAllocations := make([]float64, n)
+				for idx, node := range s.Nodes {
+					newAllocations[idx] = node.Allocation
+				}
+				newAllocations[nodeID] = clampReflect(
+					newAllocations[nodeID]+exchange, gaMinAllocation, gaMaxAllocation)
+				newAllocations[neighbor] = clampReflect(
+					newAllocations[neighbor]-exchange, gaMinAllocation, gaMaxAllocation)
+				s.Normalize(newAllocations)
+
+				s.CostHistory = append(s.CostHistory, s.ComputeCost())
+				converged := tracker.record(nodeID, neighbor, exchange, epsilon)
+				s.mut.Unlock()
+
+				if converged {
+					stop()
+					return
+				}
+
+				time.Sleep(asyncTickJitter)
+			}
+		}(i)
+	}
+
+	clients.Wait()
+	stop() // opreste si serverele, indiferent daca s-a ajuns la convergenta sau la maxTicks
+	responders.Wait()
+
+	printFinalState(s)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAsyncPairwiseAlgorithmPreservesAllocationSum reproduce un sistem cu
+// lambda/mu asimetrice unde reflectarea independenta a celor doua capete ale
+// unui schimb putea face ca Σxi sa se departeze de 1 (si costul final sa
+// devina +Inf). AsyncPairwiseAlgorithm trebuie sa normalizeze dupa fiecare schimb
+func TestAsyncPairwiseAlgorithmPreservesAllocationSum(t *testing.T) {
+	mu := []float64{2, 8, 2, 8, 2}
+	lambdas := []float64{1.5, 0.1, 1.5, 0.1, 1.5}
+
+	s := CreateNewSystem(lambdas, mu, 3.0, nil)
+	topo := Ring(len(lambdas))
+
+	AsyncPairwiseAlgorithm(s, topo, 0.05, 500, 1e-5, 20)
+
+	total := 0.0
+	for _, node := range s.Nodes {
+		total += node.Allocation
+	}
+	if math.Abs(total-1.0) > 1e-6 {
+		t.Fatalf("final allocations sum to %v, want 1.0", total)
+	}
+
+	if math.IsInf(s.ComputeCost(), 1) {
+		t.Fatalf("final system is unstable (cost = +Inf)")
+	}
+}
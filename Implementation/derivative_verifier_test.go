@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+// TestVerifyDerivativesLowK reproduce un sistem cu K mic relativ la λ/μ, unde
+// d²U/dxi² e mic si Compute1onSecondDerivative clampeaza ki la 5.0. Inainte
+// de a compara fata de ComputeSecondDerivative (bruta), verificatorul esua
+// aici desi modelul de cost e corect
+func TestVerifyDerivativesLowK(t *testing.T) {
+	mu := []float64{5, 5, 5}
+	lambdas := []float64{0.2, 0.2, 0.2}
+	K := 0.1
+
+	s := CreateNewSystem(lambdas, mu, K, nil)
+
+	if err := VerifyDerivatives(s, 1e-2); err != nil {
+		t.Fatalf("VerifyDerivatives() failed on a valid low-K system: %v", err)
+	}
+}
+
+// TestVerifyDerivativesNearInstability reproduce un nod foarte aproape de
+// saturatie (Mu-TotalLambda*xi aproape de 0), unde xi+h trece peste pragul de
+// stabilitate si ComputeCost intoarce +Inf. Diferentele centrate devin +Inf,
+// extrapolarea Richardson 4·Inf - Inf da NaN, iar "NaN > tol" e mereu false
+// in Go: VerifyDerivatives
+// trebuie sa esueze explicit in loc sa raporteze succes
+func TestVerifyDerivativesNearInstability(t *testing.T) {
+	s := CreateNewSystem([]float64{4.99}, []float64{5}, 1.0, nil)
+	s.Nodes[0].Allocation = 0.9999
+
+	if err := VerifyDerivatives(s, 1e-3); err == nil {
+		t.Fatalf("VerifyDerivatives() succeeded near an instability boundary where finite differences are NaN")
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// |===========================|
+// |Genetic Algorithm (gradient-free baseline)|
+// |===========================|
+// Fiecare cromozom e un vector de alocari (x1..xn) cu suma 1. Spre deosebire
+// de algoritmii bazati pe derivate, GA nu presupune convexitatea costului,
+// deci serveste si ca verificare ca acestia gasesc un optim real (nu un saddle)
+
+const (
+	gaMinAllocation = 0.001
+	gaMaxAllocation = 0.90
+	gaMutationSigma = 0.1 // deviatia standard a zgomotului gaussian de mutatie
+)
+
+// sampleGamma genereaza o variabila Gamma(shape=alpha, scale=1) prin
+// metoda Marsaglia-Tsang (alpha >= 1); folosita la esantionarea Dirichlet
+func sampleGamma(alpha float64, rng *rand.Rand) float64 {
+	d := alpha - 1.0/3.0
+	c := 1.0 / math.Sqrt(9.0*d)
+
+	for {
+		x := rng.NormFloat64()
+		v := 1.0 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+
+		u := rng.Float64()
+		if u < 1.0-0.0331*(x*x*x*x) {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1.0-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// sampleDirichlet genereaza un vector de alocari cu suma 1, esantionat
+// dintr-o distributie Dirichlet(alpha=1,...,1) (uniforma pe simplex)
+func sampleDirichlet(n int, rng *rand.Rand) []float64 {
+	sample := make([]float64, n)
+	total := 0.0
+	for i := range sample {
+		sample[i] = sampleGamma(1.0, rng)
+		total += sample[i]
+	}
+	for i := range sample {
+		sample[i] /= total
+	}
+	return sample
+}
+
+// clampReflect restrictioneaza o valoare la [lo, hi] prin reflectare
+// (in loc de simpla taiere), pentru a nu aglomera mutatiile pe margine
+func clampReflect(x, lo, hi float64) float64 {
+	for x < lo || x > hi {
+		if x < lo {
+			x = 2*lo - x
+		}
+		if x > hi {
+			x = 2*hi - x
+		}
+	}
+	return x
+}
+
+// normalizeChromosome aduce vectorul de alocari la suma 1
+func normalizeChromosome(chromosome []float64) {
+	total := 0.0
+	for _, x := range chromosome {
+		total += x
+	}
+	for i := range chromosome {
+		chromosome[i] /= total
+	}
+}
+
+// evaluateChromosome aplica temporar alocarile din cromozom sistemului,
+// calculeaza costul si restaureaza alocarile initiale
+func evaluateChromosome(s *System, chromosome []float64) float64 {
+	original := make([]float64, len(s.Nodes))
+	for i, node := range s.Nodes {
+		original[i] = node.Allocation
+		node.Allocation = chromosome[i]
+	}
+
+	cost := s.ComputeCost()
+
+	for i, node := range s.Nodes {
+		node.Allocation = original[i]
+	}
+	return cost
+}
+
+// fitness = 1/(1+cost); sisteme instabile (cost infinit) primesc fitness 0
+func fitnessFromCost(cost float64) float64 {
+	if math.IsInf(cost, 1) {
+		return 0
+	}
+	return 1.0 / (1.0 + cost)
+}
+
+// tournamentSelect alege cel mai bun din 3 indivizi alesi aleator
+func tournamentSelect(population [][]float64, fitness []float64, rng *rand.Rand) []float64 {
+	bestIdx := rng.Intn(len(population))
+	for k := 0; k < 2; k++ {
+		candidate := rng.Intn(len(population))
+		if fitness[candidate] > fitness[bestIdx] {
+			bestIdx = candidate
+		}
+	}
+	return population[bestIdx]
+}
+
+// arithmeticCrossover combina doi parinti intr-un copil prin blend aleator,
+// apoi renormalizeaza ca suma alocarilor sa ramana 1
+func arithmeticCrossover(parent1, parent2 []float64, rng *rand.Rand) []float64 {
+	n := len(parent1)
+	child := make([]float64, n)
+	beta := rng.Float64()
+	for i := range child {
+		child[i] = beta*parent1[i] + (1-beta)*parent2[i]
+	}
+	normalizeChromosome(child)
+	return child
+}
+
+// gaussianMutate aplica zgomot gaussian pe fiecare gena cu probabilitate pm,
+// reflectand rezultatul in [gaMinAllocation, gaMaxAllocation]
+func gaussianMutate(chromosome []float64, pm float64, rng *rand.Rand) {
+	for i := range chromosome {
+		if rng.Float64() < pm {
+			chromosome[i] += rng.NormFloat64() * gaMutationSigma
+			chromosome[i] = clampReflect(chromosome[i], gaMinAllocation, gaMaxAllocation)
+		}
+	}
+	normalizeChromosome(chromosome)
+}
+
+// GeneticAlgorithm cauta alocarea optima printr-un algoritm genetic clasic:
+// selectie turneu, crossover aritmetic si mutatie gaussiana cu reflectare.
+// Nu presupune nimic despre forma costului, deci functioneaza si cand
+// acesta nu mai e convex (de exemplu dupa introducerea matricei Cij)
+func GeneticAlgorithm(s *System, popSize, generations int, pc, pm float64) {
+	fmt.Println("\n" + strings.Repeat("=", 50))
+	fmt.Println("Genetic Algorithm")
+	fmt.Println(strings.Repeat("=", 50))
+
+	n := len(s.Nodes)
+	rng := rand.New(rand.NewSource(42))
+
+	population := make([][]float64, popSize)
+	for i := range population {
+		population[i] = sampleDirichlet(n, rng)
+	}
+
+	// initializat cu un membru real al populatiei (nu nil) pentru ca, daca
+	// intreaga populatie initiala e infezabila (cost +Inf), sa avem tot o
+	// alocare valida de aplicat la final in loc sa panicam pe index 0
+	bestChromosome := append([]float64(nil), population[0]...)
+	bestCost := math.Inf(1)
+
+	for generation := 0; generation < generations; generation++ {
+		fitness := make([]float64, popSize)
+		costs := make([]float64, popSize)
+		for i, chromosome := range population {
+			costs[i] = evaluateChromosome(s, chromosome)
+			fitness[i] = fitnessFromCost(costs[i])
+
+			if costs[i] < bestCost {
+				bestCost = costs[i]
+				bestChromosome = append([]float64(nil), chromosome...)
+			}
+		}
+
+		// elitism: pastram cel mai bun cromozom gasit pana acum
+		nextPopulation := make([][]float64, 0, popSize)
+		nextPopulation = append(nextPopulation, append([]float64(nil), bestChromosome...))
+
+		for len(nextPopulation) < popSize {
+			parent1 := tournamentSelect(population, fitness, rng)
+			parent2 := tournamentSelect(population, fitness, rng)
+
+			var child []float64
+			if rng.Float64() < pc {
+				child = arithmeticCrossover(parent1, parent2, rng)
+			} else {
+				child = append([]float64(nil), parent1...)
+			}
+
+			gaussianMutate(child, pm, rng)
+			nextPopulation = append(nextPopulation, child)
+		}
+		population = nextPopulation
+
+		s.CostHistory = append(s.CostHistory, bestCost)
+
+		if generation%10 == 0 {
+			fmt.Printf("Gen %3d: Best cost = %.4f\n", generation, bestCost)
+		}
+	}
+
+	// aplicam cea mai buna alocare gasita sistemului, pentru raportare
+	for i, node := range s.Nodes {
+		node.Allocation = bestChromosome[i]
+	}
+
+	printFinalState(s)
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+// TestGeneticAlgorithmAllInfeasiblePopulation reproduce un sistem supraincarcat
+// in care fiecare cromozom din populatia initiala e instabil (cost +Inf).
+// GeneticAlgorithm trebuie sa produca tot o alocare valida, nu sa panice
+// pe bestChromosome[i] cand bestChromosome n-a fost niciodata actualizat
+func TestGeneticAlgorithmAllInfeasiblePopulation(t *testing.T) {
+	n := 8
+	mu := make([]float64, n)
+	lambdas := make([]float64, n)
+	for i := range mu {
+		mu[i] = 0.3
+		lambdas[i] = 1.0
+	}
+
+	s := CreateNewSystem(lambdas, mu, 1.0, nil)
+
+	GeneticAlgorithm(s, 10, 3, 0.8, 0.1)
+
+	total := 0.0
+	for _, node := range s.Nodes {
+		total += node.Allocation
+	}
+	if total < 0.99 || total > 1.01 {
+		t.Fatalf("final allocations sum to %v, want ~1.0", total)
+	}
+}
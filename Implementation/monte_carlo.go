@@ -0,0 +1,258 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// |================================|
+// |Monte Carlo si optimizare robusta|
+// |================================|
+// Cele trei/patru metode de alocare de mai sus presupun ca lambda e fixat.
+// In realitate traficul e incert, deci fixam alocarea gasita de optimizator
+// si reesantionam lambda pentru a vedea cat de fiabil e sistemul rezultat
+
+const mcHistogramBins = 20
+
+// MCReport rezuma evaluarea sub incertitudine a unei alocari fixate: cost
+// mediu si varianta peste esantioane, histograma costurilor si probabilitatea
+// ca sistemul sa devina instabil (ComputeCost intoarce +Inf, adica numitorul
+// timpului de raspuns al unui nod e <= 0.01)
+type MCReport struct {
+	MeanCost        float64
+	CostVariance    float64
+	Histogram       []int     // numar de esantioane per bin
+	HistogramEdges  []float64 // margine stanga a fiecarui bin (len(Histogram) elemente)
+	FailProbability float64   // P(sistem instabil) peste esantioane
+}
+
+// MonteCarloEvaluate fixeaza alocarile curente ale lui s, esantioneaza
+// nSamples vectori de lambda din s.LambdaSpecs, recalculeaza costul si
+// stabilitatea pentru fiecare, apoi intoarce statisticile agregate.
+// Alocarile si lambda initiale ale lui s sunt restaurate la final
+func MonteCarloEvaluate(s *System, nSamples int, seed int64) MCReport {
+	rng := rand.New(rand.NewSource(seed))
+
+	originalLambda := make([]float64, len(s.Nodes))
+	for i, node := range s.Nodes {
+		originalLambda[i] = node.Lambda
+	}
+	originalTotal := s.TotalLambda
+
+	costs := make([]float64, 0, nSamples)
+	failures := 0
+
+	for sample := 0; sample < nSamples; sample++ {
+		total := 0.0
+		for i, spec := range s.LambdaSpecs {
+			lambda := spec.Sample(rng)
+			s.Nodes[i].Lambda = lambda
+			total += lambda
+		}
+		s.TotalLambda = total
+
+		cost := s.ComputeCost()
+		if math.IsInf(cost, 1) {
+			failures++
+			continue
+		}
+		costs = append(costs, cost)
+	}
+
+	for i, node := range s.Nodes {
+		node.Lambda = originalLambda[i]
+	}
+	s.TotalLambda = originalTotal
+
+	report := MCReport{FailProbability: float64(failures) / float64(nSamples)}
+	if len(costs) == 0 {
+		return report
+	}
+
+	report.MeanCost, report.CostVariance = meanAndVariance(costs)
+	report.Histogram, report.HistogramEdges = buildHistogram(costs, mcHistogramBins)
+	return report
+}
+
+// meanAndVariance calculeaza media si varianta (populationala) unui esantion
+func meanAndVariance(values []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		diff := v - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(values))
+
+	return mean, variance
+}
+
+// buildHistogram imparte esantioanele in "bins" intervale egale intre minim si maxim
+func buildHistogram(values []float64, bins int) ([]int, []float64) {
+	minV, maxV := values[0], values[0]
+	for _, v := range values {
+		if v < minV {
+			minV = v
+		}
+		if v > maxV {
+			maxV = v
+		}
+	}
+	if maxV == minV {
+		maxV = minV + 1 // evitam bin-uri de latime 0 cand toate esantioanele coincid
+	}
+
+	width := (maxV - minV) / float64(bins)
+	edges := make([]float64, bins)
+	for i := range edges {
+		edges[i] = minV + float64(i)*width
+	}
+
+	counts := make([]int, bins)
+	for _, v := range values {
+		bin := int((v - minV) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		counts[bin]++
+	}
+	return counts, edges
+}
+
+// |=================|
+// |Optimizare robusta|
+// |=================|
+
+// robustStepSize este amplitudinea perturbatiei SPSA aplicate alocarilor
+const robustStepSize = 0.01
+
+// RobustOptimize ajusteaza alocarea sub incertitudinea lambda din
+// s.LambdaSpecs prin pasi de gradient stocastic stil SPSA.
+// Alocarea e echilibrata o singura data, determinist, pe lambda nominal
+// (SecondDerivativeAlgorithm), ca punct de plecare. Dupa aceea, fiecare
+// iteratie exterioara aplica DOAR un pas SPSA peste alocarea precedenta:
+// se esantioneaza cate un lambda independent pentru evaluarile x+c·Δ si
+// x-c·Δ si se formeaza diferenta centrata (costPlus - costMinus)/(2c).
+// Pasul e aceasta estimare scalata cu alpha·beta; Std[cost] nu e estimat si
+// nu intra in gradient, deci beta modifica doar marimea pasului, iar
+// obiectivul E[cost] + beta·Std[cost] nu e minimizat explicit. Daca am re-rula
+// SecondDerivativeAlgorithm pana la convergenta la fiecare iteratie, am
+// sterge pasii SPSA anteriori si am ajunge mereu inapoi la optimul
+// determinist (nu la cel robust)
+func RobustOptimize(s *System, outerIters int, beta, alpha float64, innerIters int, epsilon float64, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	n := len(s.Nodes)
+
+	nominalLambda := make([]float64, n)
+	for i, spec := range s.LambdaSpecs {
+		nominalLambda[i] = spec.Mean
+	}
+
+	// echilibru initial (o singura data) pe lambda nominal; SPSA pleaca de aici
+	applyLambda(s, nominalLambda)
+	SecondDerivativeAlgorithm(s, alpha, innerIters, epsilon)
+
+	for outer := 0; outer < outerIters; outer++ {
+		delta := randomSignVector(n, rng)
+
+		xPlus := perturbedAllocation(s, delta, robustStepSize)
+		costPlus := evaluateAt(s, xPlus, sampleLambdaVector(s.LambdaSpecs, rng))
+
+		xMinus := perturbedAllocation(s, delta, -robustStepSize)
+		costMinus := evaluateAt(s, xMinus, sampleLambdaVector(s.LambdaSpecs, rng))
+
+		riskGradScale := (costPlus - costMinus) / (2 * robustStepSize)
+
+		newAllocations := make([]float64, n)
+		for i := range newAllocations {
+			riskGrad := riskGradScale / delta[i] // SPSA: gradientul pe componenta i
+			newAllocations[i] = math.Max(gaMinAllocation,
+				math.Min(gaMaxAllocation, s.Nodes[i].Allocation-alpha*beta*riskGrad))
+		}
+		s.Normalize(newAllocations)
+
+		s.CostHistory = append(s.CostHistory, s.ComputeCost())
+	}
+
+	printFinalState(s)
+}
+
+// applyLambda seteaza lambda fiecarui nod (si TotalLambda) la valorile date
+func applyLambda(s *System, lambdas []float64) {
+	total := 0.0
+	for i, lambda := range lambdas {
+		s.Nodes[i].Lambda = lambda
+		total += lambda
+	}
+	s.TotalLambda = total
+}
+
+// sampleLambdaVector extrage un esantion independent pentru fiecare nod
+func sampleLambdaVector(specs []LambdaSpec, rng *rand.Rand) []float64 {
+	lambdas := make([]float64, len(specs))
+	for i, spec := range specs {
+		lambdas[i] = spec.Sample(rng)
+	}
+	return lambdas
+}
+
+// randomSignVector genereaza directia de perturbatie SPSA: +1/-1 pe fiecare componenta
+func randomSignVector(n int, rng *rand.Rand) []float64 {
+	signs := make([]float64, n)
+	for i := range signs {
+		if rng.Float64() < 0.5 {
+			signs[i] = -1
+		} else {
+			signs[i] = 1
+		}
+	}
+	return signs
+}
+
+// perturbedAllocation calculeaza alocarile curente deplasate cu c·delta,
+// limitate la [gaMinAllocation, gaMaxAllocation] si renormalizate la suma 1
+func perturbedAllocation(s *System, delta []float64, c float64) []float64 {
+	x := make([]float64, len(s.Nodes))
+	total := 0.0
+	for i, node := range s.Nodes {
+		x[i] = math.Max(gaMinAllocation, math.Min(gaMaxAllocation, node.Allocation+c*delta[i]))
+		total += x[i]
+	}
+	for i := range x {
+		x[i] /= total
+	}
+	return x
+}
+
+// evaluateAt calculeaza costul sistemului pentru o alocare si un vector de
+// lambda date, restaurand starea initiala a lui s dupa calcul
+func evaluateAt(s *System, allocations, lambdas []float64) float64 {
+	originalAlloc := make([]float64, len(s.Nodes))
+	originalLambda := make([]float64, len(s.Nodes))
+	originalTotal := s.TotalLambda
+
+	total := 0.0
+	for i, node := range s.Nodes {
+		originalAlloc[i] = node.Allocation
+		originalLambda[i] = node.Lambda
+		node.Allocation = allocations[i]
+		node.Lambda = lambdas[i]
+		total += lambdas[i]
+	}
+	s.TotalLambda = total
+
+	cost := s.ComputeCost()
+
+	for i, node := range s.Nodes {
+		node.Allocation = originalAlloc[i]
+		node.Lambda = originalLambda[i]
+	}
+	s.TotalLambda = originalTotal
+
+	return cost
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRobustOptimizeDivergesFromDeterministicOptimum reproduce regresia unde
+// RobustOptimize re-rula SecondDerivativeAlgorithm pana la convergenta la
+// fiecare iteratie exterioara, stergand pasii SPSA anteriori si ajungand
+// mereu la acelasi optim determinist. Dupa fix, cele doua alocari trebuie
+// sa difere (pasii SPSA pe costuri cu lambda esantionat persista intre iteratii)
+func TestRobustOptimizeDivergesFromDeterministicOptimum(t *testing.T) {
+	mu := []float64{5, 5, 5, 5}
+	specs := []LambdaSpec{
+		{Dist: DistNormal, Mean: 0.6, Std: 0.25},
+		{Dist: DistNormal, Mean: 0.6, Std: 0.05},
+		{Dist: DistNormal, Mean: 0.6, Std: 0.05},
+		{Dist: DistNormal, Mean: 0.6, Std: 0.05},
+	}
+	lambdas := make([]float64, len(specs))
+	for i, spec := range specs {
+		lambdas[i] = spec.Mean
+	}
+
+	deterministic := CreateNewSystem(lambdas, mu, 2.0, nil)
+	SecondDerivativeAlgorithm(deterministic, 0.005, 1000, 0.00001)
+
+	robust := CreateNewSystem(lambdas, mu, 2.0, nil)
+	robust.LambdaSpecs = specs
+	RobustOptimize(robust, 50, 2.0, 0.01, 1000, 0.00001, 1)
+
+	maxDiff := 0.0
+	for i := range robust.Nodes {
+		diff := math.Abs(robust.Nodes[i].Allocation - deterministic.Nodes[i].Allocation)
+		if diff > maxDiff {
+			maxDiff = diff
+		}
+	}
+
+	if maxDiff < 1e-4 {
+		t.Fatalf("RobustOptimize allocation matches deterministic optimum (maxDiff=%v); SPSA steps aren't persisting", maxDiff)
+	}
+}
@@ -39,6 +39,9 @@ func PlotConvergence(systems []*System, names []string, filename string) error {
 		{R: 255, A: 255},
 		{G: 255, A: 255},
 		{B: 255, A: 255},
+		{R: 200, G: 0, B: 200, A: 255},
+		{R: 200, G: 150, A: 255},
+		{R: 0, G: 150, B: 150, A: 255},
 	}
 
 	for i, sys := range systems {
@@ -188,3 +191,41 @@ func PlotAllocationEvolution(sys *System, allocationHistory [][]float64, filenam
 
 	return p.Save(8*vg.Inch, 6*vg.Inch, filename)
 }
+
+// PlotCostDistribution genereaza o histograma a costurilor esantionate prin Monte Carlo
+func PlotCostDistribution(report MCReport, filename string) error {
+	dir := filepath.Dir(filename)
+	if err := CreateDir(dir); err != nil {
+		return fmt.Errorf("Eroare la creare %s: %w", dir, err)
+	}
+
+	if len(report.Histogram) == 0 {
+		return fmt.Errorf("raport Monte Carlo fara esantioane valide, nimic de plotat")
+	}
+
+	p := plot.New()
+
+	p.Title.Text = "Distributia costului (Monte Carlo)"
+	p.X.Label.Text = "Cost (marginea de jos a bin-ului)"
+	p.Y.Label.Text = "Frecventa"
+
+	bins := len(report.Histogram)
+	values := make(plotter.Values, bins)
+	labels := make([]string, bins)
+	for i := 0; i < bins; i++ {
+		values[i] = float64(report.Histogram[i])
+		labels[i] = fmt.Sprintf("%.2f", report.HistogramEdges[i])
+	}
+
+	bar, err := plotter.NewBarChart(values, vg.Points(15))
+	if err != nil {
+		return err
+	}
+	bar.Color = plotutil.Color(0)
+	bar.LineStyle.Width = vg.Length(0)
+
+	p.Add(bar)
+	p.NominalX(labels...)
+
+	return p.Save(8*vg.Inch, 6*vg.Inch, filename)
+}
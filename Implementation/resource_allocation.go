@@ -25,14 +25,32 @@ type Node struct {
 type System struct {
 	Nodes []*Node // lista de noduri
 	// factor ponderare(cat de important e timpul vs costul de comunicare)
-	K           float64    // K mare pune accent pe timp, K mic pe cost
-	TotalLambda float64    // suma tuturor ratelor de sosire
-	CostHistory []float64  // istoric costuri pentru analiza convergentei
-	mut         sync.Mutex // mutex pentru acces concurent/sincronizat
+	K           float64      // K mare pune accent pe timp, K mic pe cost
+	TotalLambda float64      // suma tuturor ratelor de sosire
+	Cij         [][]float64  // Cij[i][j] = costul comunicarii nodului i cu nodul j, ponderat de xj
+	CostHistory []float64    // istoric costuri pentru analiza convergentei
+	LambdaSpecs []LambdaSpec // optional: distributiile din care au fost derivate Lambda (pentru Monte Carlo)
+	mut         sync.Mutex   // mutex pentru acces concurent/sincronizat
+}
+
+// defaultCij construieste o matrice Cij uniforma (0.5 peste tot), echivalenta
+// cu costul de comunicare constant folosit inainte de introducerea Cij
+func defaultCij(n int) [][]float64 {
+	cij := make([][]float64, n)
+	for i := range cij {
+		cij[i] = make([]float64, n)
+		for j := range cij[i] {
+			cij[i][j] = 0.5
+		}
+	}
+	return cij
 }
 
 // CreateNewSystem creeaza un sistem nou cu alocare uniforma initiala
-func CreateNewSystem(lambdas []float64, mu float64, K float64) *System {
+// mu contine rata de servire pentru fiecare nod (eterogen), iar cij este
+// matricea de costuri de comunicare per-legatura; daca cij este nil se
+// foloseste o matrice uniforma (0.5), echivalenta cu comportamentul vechi
+func CreateNewSystem(lambdas []float64, mu []float64, K float64, cij [][]float64) *System {
 	n := len(lambdas)
 	nodes := make([]*Node, n)
 	totalLambda := 0.0
@@ -40,17 +58,22 @@ func CreateNewSystem(lambdas []float64, mu float64, K float64) *System {
 		nodes[i] = &Node{ // initializare noduri
 			ID:         i,
 			Lambda:     lambda,
-			Mu:         mu,
+			Mu:         mu[i],
 			Allocation: 1.0 / float64(n), // initializare uniforma
 		}
 		totalLambda += lambda
 	}
 
+	if cij == nil {
+		cij = defaultCij(n)
+	}
+
 	// creare si returnare sistem
 	return &System{
 		Nodes:       nodes,
 		K:           K,
 		TotalLambda: totalLambda,
+		Cij:         cij,
 		CostHistory: make([]float64, 0),
 	}
 }
@@ -60,53 +83,77 @@ func CreateNewSystem(lambdas []float64, mu float64, K float64) *System {
 // |=================|
 
 // ComputeCost calculeaza costul total al sistemului bazat pe costul de comunicare si timpul de raspuns
+// U = Σ_i λi·(Σ_j xj·Cij + K·Ti), unde Cij este costul legaturii i-j ponderat de alocarea destinatiei
 func (s *System) ComputeCost() float64 {
 	totalCost := 0.0
 
-	for _, node := range s.Nodes {
-		xi := node.Allocation   // rata de resursa alocata
+	for i, node := range s.Nodes {
 		lambda_i := node.Lambda // rata de sosire(trafic primit)
 
-		// Ti = 1 / (μ - Σλ · xi)
-		denominator := node.Mu - s.TotalLambda*xi // rata efectiva de sosire/numitorul
-		if denominator <= 0.01 {                  // verificare stabilitate sistem
+		// Ti = 1 / (μi - Σλ · xi)
+		denominator := node.Mu - s.TotalLambda*node.Allocation // rata efectiva de sosire/numitorul
+		if denominator <= 0.01 {                               // verificare stabilitate sistem
 			// sistem instabil, cost infinit
 			return math.Inf(1)
 		}
 
 		Ti := 1.0 / denominator // timp mediu de raspuns al nodului i
 
-		Ci := 0.5 // cost comunicare simplificat
+		// Σ_j xj·Cij = costul de comunicare al nodului i, ponderat de alocarile tuturor nodurilor
+		commCost := 0.0
+		for j, other := range s.Nodes {
+			commCost += other.Allocation * s.Cij[i][j]
+		}
 
-		// Cost = (Ci + K·Ti) · λi
-		totalCost += (Ci + s.K*Ti) * lambda_i
+		// Cost = λi · (Σ_j xj·Cij + K·Ti)
+		totalCost += lambda_i * (commCost + s.K*Ti)
 	}
 	return totalCost
 }
 
 // ComputeFirstDerivative calculeaza dU/dxi pentru nodul i
+// dU/dxi = λi·Cii + Σ_{k≠i} λk·Cki + K·λi·Σλ / (μi - Σλ·xi)²
 func (s *System) ComputeFirstDerivative(nodeIndex int) float64 {
 	node := s.Nodes[nodeIndex]
 	xi := node.Allocation // rata de resursa alocata
 
 	denominator := node.Mu - s.TotalLambda*xi // rata efectiva de sosire/numitorul
 
-	// dU/dxi = K · λi · Σλ / (μ - Σλ·xi)²
-	derivative := s.K * node.Lambda * s.TotalLambda / (denominator * denominator)
+	// termenul de timp: depinde doar de xi, prin nodul i insusi
+	timeTerm := s.K * node.Lambda * s.TotalLambda / (denominator * denominator)
 
-	return derivative
+	// termenul de comunicare: xi apare in suma fiecarui nod k (prin Cki),
+	// nu doar in suma proprie (Cii)
+	commTerm := node.Lambda * s.Cij[nodeIndex][nodeIndex]
+	for k, other := range s.Nodes {
+		if k == nodeIndex {
+			continue
+		}
+		commTerm += other.Lambda * s.Cij[k][nodeIndex]
+	}
+
+	return commTerm + timeTerm
 }
 
-// Compute1onSecondDerivative calculeaza ki = 1 / (d²U/dxi²) pentru nodul i
-func (s *System) Compute1onSecondDerivative(nodeIndex int) float64 {
+// ComputeSecondDerivative calculeaza d²U/dxi² pentru nodul i, fara nicio
+// limitare. Compute1onSecondDerivative clampeaza ki pentru stabilitatea
+// algoritmilor de optimizare; cand e nevoie de valoarea analitica bruta
+// (de exemplu in VerifyDerivatives), se foloseste aceasta functie
+func (s *System) ComputeSecondDerivative(nodeIndex int) float64 {
 	node := s.Nodes[nodeIndex]
 	xi := node.Allocation // rata de resursa alocata
 
 	denominator := node.Mu - s.TotalLambda*xi // rata efectiva de sosire/numitorul
 
 	// d²U/dxi² = 2·K·λi·(Σλ)² / (μ - Σλ·xi)³
-	secondDerivative := 2.0 * s.K * node.Lambda *
+	return 2.0 * s.K * node.Lambda *
 		(s.TotalLambda * s.TotalLambda) / (denominator * denominator * denominator)
+}
+
+// Compute1onSecondDerivative calculeaza ki = 1 / (d²U/dxi²) pentru nodul i,
+// limitat la 5.0 pentru stabilitatea pasului in algoritmii de optimizare
+func (s *System) Compute1onSecondDerivative(nodeIndex int) float64 {
+	secondDerivative := s.ComputeSecondDerivative(nodeIndex)
 
 	if secondDerivative == 0 { // evitam impartirea la 0
 		return 1.0
@@ -382,10 +429,22 @@ func printFinalState(s *System) {
 	fmt.Printf("Cost final: %.4f", s.ComputeCost())
 }
 
+// hasFlag verifica daca un argument apare in lista de argumente linie de comanda
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
+
 type Config struct {
-	Mu      float64   `json:"mu"`
-	Lambdas []float64 `json:"lambdas"`
-	K       float64   `json:"K"`
+	Mu       []float64      `json:"mu"`      // rata de servire per nod (eterogen)
+	Lambdas  []LambdaSpec   `json:"lambdas"` // rata de sosire per nod: valoare fixa sau distributie
+	K        float64        `json:"K"`
+	Cij      [][]float64    `json:"Cij"`      // matrice costuri comunicare per legatura; omis => uniforma (0.5)
+	Topology TopologyConfig `json:"topology"` // topologia folosita de PairwiseAlgorithm / AsyncPairwiseAlgorithm
 }
 
 func main() {
@@ -412,33 +471,78 @@ func main() {
 	}
 
 	mu := config.Mu
-	lambdas := config.Lambdas
+	lambdaSpecs := config.Lambdas
 	K := config.K
+	cij := config.Cij
+
+	if len(mu) != len(lambdaSpecs) {
+		fmt.Println("Error: mu si lambdas trebuie sa aiba aceeasi lungime")
+		return
+	}
+
+	// valorile medii/fixe servesc drept lambda "nominal" pentru optimizarea determinista
+	lambdas := make([]float64, len(lambdaSpecs))
+	for i, spec := range lambdaSpecs {
+		lambdas[i] = spec.Mean
+	}
 
 	fmt.Printf("Noduri: %d\n", len(lambdas))
-	fmt.Printf("Lambda values: %v\n", lambdas)
-	fmt.Printf("μ (service rate): %.1f\n\n", mu)
+	fmt.Printf("Lambda values (nominal): %v\n", lambdas)
+	fmt.Printf("μ (service rates): %v\n\n", mu)
+
+	// Pas optional: verificare derivate analitice vs numerice inainte de rulare
+	if hasFlag(os.Args, "--verify") {
+		verifySystem := CreateNewSystem(lambdas, mu, K, cij)
+		fmt.Println(strings.Repeat("=", 60))
+		fmt.Println("Verificare derivate (analitic vs diferente finite)")
+		fmt.Println(strings.Repeat("=", 60))
+		if err := VerifyDerivatives(verifySystem, 1e-3); err != nil {
+			fmt.Printf("Verificare esuata: %v\n", err)
+			return
+		}
+		fmt.Println("Verificare trecuta cu succes.")
+	}
 
 	// Testare configuratii foarte precise
 
 	// Test 1: Prima derivata
-	system1 := CreateNewSystem(lambdas, mu, K)
+	system1 := CreateNewSystem(lambdas, mu, K, cij)
 	// simplificat pentru precizie redusa: 0.02, 200, 0.001
 	FirstDerivativeAlgorithm(system1, 0.01, 1500, 0.00001)
 
 	// Test 2: Derivata a doua
-	system2 := CreateNewSystem(lambdas, mu, K)
+	system2 := CreateNewSystem(lambdas, mu, K, cij)
 	// simplificat pentru precizie redusa: 0.01, 100, 0.001
 	SecondDerivativeAlgorithm(system2, 0.005, 1000, 0.00001)
 
-	// Test 3: Pairwise
-	system3 := CreateNewSystem(lambdas, mu, K)
-	topology := []Edge{
-		{0, 1}, {0, 2}, {0, 3},
-		{1, 2}, {1, 3}, {2, 3},
+	// Evaluare Monte Carlo: cat de fiabila e alocarea gasita sub incertitudinea lambda
+	system2.LambdaSpecs = lambdaSpecs
+	mcReport := MonteCarloEvaluate(system2, 2000, 1)
+	fmt.Printf("\nMonte Carlo (n=2000) pe alocarea Second Derivative:\n")
+	fmt.Printf("  Cost mediu=%.4f, Std=%.4f, P(instabil)=%.2f%%\n",
+		mcReport.MeanCost, math.Sqrt(mcReport.CostVariance), mcReport.FailProbability*100)
+	if err := PlotCostDistribution(mcReport, "plots/cost_distribution.png"); err != nil {
+		fmt.Printf("Eroare generare plot: %v\n", err)
 	}
+
+	// Test 3: Pairwise, pe topologia selectata din config.json (implicit graf complet)
+	topo := BuildTopology(config.Topology, len(lambdas))
+	system3 := CreateNewSystem(lambdas, mu, K, cij)
 	// simplificat pentru precizie redusa: 0.05, 200, 0.001
-	PairwiseAlgorithm(system3, topology, 0.02, 500, 0.00001)
+	PairwiseAlgorithm(system3, topo.Edges(), 0.02, 500, 0.00001)
+
+	// Test 4: Genetic (fara gradient, pentru variante non-convexe ale costului)
+	system4 := CreateNewSystem(lambdas, mu, K, cij)
+	GeneticAlgorithm(system4, 60, 200, 0.8, 0.1)
+
+	// Test 5: Optimizare robusta, pasi SPSA pe costuri cu lambda esantionat din distributii
+	system5 := CreateNewSystem(lambdas, mu, K, cij)
+	system5.LambdaSpecs = lambdaSpecs
+	RobustOptimize(system5, 50, 0.5, 0.01, 100, 0.00001, 2)
+
+	// Test 6: Pairwise gossip asincron, pe aceeasi topologie ca Test 3
+	system6 := CreateNewSystem(lambdas, mu, K, cij)
+	AsyncPairwiseAlgorithm(system6, topo, 0.02, 2000, 0.00001, 20)
 
 	// Sumar
 	fmt.Println("\n" + strings.Repeat("=", 60))
@@ -452,12 +556,23 @@ func main() {
 	fmt.Printf("%-20s %-12d %.4f      xxx\n",
 		"Second Derivative", len(system2.CostHistory), system2.ComputeCost())
 	fmt.Printf("%-20s %-12d %.4f      xx\n",
-		"Pairwise", len(system3.CostHistory), system3.ComputeCost())
+		fmt.Sprintf("Pairwise (%s)", topo.Name()), len(system3.CostHistory), system3.ComputeCost())
+	fmt.Printf("%-20s %-12d %.4f      -\n",
+		"Genetic", len(system4.CostHistory), system4.ComputeCost())
+	fmt.Printf("%-20s %-12d %.4f      -\n",
+		"Robust", len(system5.CostHistory), system5.ComputeCost())
+	fmt.Printf("%-20s %-12d %.4f      xx\n",
+		fmt.Sprintf("Async Pairwise (%s)", topo.Name()), len(system6.CostHistory), system6.ComputeCost())
 	fmt.Println(strings.Repeat("=", 60))
 
 	// Generare ploturi
-	systems := []*System{system1, system2, system3}
-	names := []string{"First Derivative", "Second Derivative", "Pairwise"}
+	systems := []*System{system1, system2, system3, system4, system5, system6}
+	names := []string{
+		"First Derivative", "Second Derivative",
+		fmt.Sprintf("Pairwise (%s)", topo.Name()),
+		"Genetic", "Robust",
+		fmt.Sprintf("Async Pairwise (%s)", topo.Name()),
+	}
 
 	// Grafic convergenta
 	if err := PlotConvergence(systems, names, "plots/convergence.png"); err != nil {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestComputeCostUniformCij verifica faptul ca, atunci cand Cij nu e
+// specificat (nil => matrice uniforma 0.5), ComputeCost reproduce formula
+// folosita inainte de introducerea costurilor per-legatura: U = Σ λi·(0.5 + K·Ti)
+func TestComputeCostUniformCij(t *testing.T) {
+	mu := []float64{5, 5, 5, 5}
+	lambdas := []float64{0.3, 0.4, 0.5, 0.2}
+	K := 2.0
+
+	s := CreateNewSystem(lambdas, mu, K, nil)
+
+	got := s.ComputeCost()
+
+	want := 0.0
+	for _, node := range s.Nodes {
+		denom := node.Mu - s.TotalLambda*node.Allocation
+		want += node.Lambda * (0.5 + K/denom)
+	}
+
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("ComputeCost() = %v, want %v (uniform-Cij regression)", got, want)
+	}
+}
+
+// TestComputeFirstDerivativeUniformCij verifica analitic vs. diferente finite
+// centrate pentru cazul uniform (Cij implicit), pentru fiecare nod
+func TestComputeFirstDerivativeUniformCij(t *testing.T) {
+	mu := []float64{5, 5, 5, 5}
+	lambdas := []float64{0.3, 0.4, 0.5, 0.2}
+	K := 2.0
+
+	s := CreateNewSystem(lambdas, mu, K, nil)
+
+	const h = 1e-4
+	for i := range s.Nodes {
+		analytic := s.ComputeFirstDerivative(i)
+
+		xi := s.Nodes[i].Allocation
+		numeric := (costAtAllocation(s, i, xi+h) - costAtAllocation(s, i, xi-h)) / (2 * h)
+
+		relErr := math.Abs(analytic-numeric) / math.Max(math.Abs(analytic), 1e-9)
+		if relErr > 1e-3 {
+			t.Fatalf("node %d: ComputeFirstDerivative() = %v, numeric = %v (relErr=%v)", i, analytic, numeric, relErr)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+)
+
+// |===============================|
+// |Lambda stocastic (incertitudine)|
+// |===============================|
+// Permite ca rata de sosire a unui nod sa fie fie o valoare fixa (ca pana acum),
+// fie o distributie din care se esantioneaza valori pentru analiza Monte Carlo
+
+// LambdaDist identifica familia de distributie folosita pentru esantionare
+type LambdaDist string
+
+const (
+	DistFixed     LambdaDist = ""          // valoare fixa, fara incertitudine
+	DistNormal    LambdaDist = "normal"    // N(mean, std)
+	DistLognormal LambdaDist = "lognormal" // log-normal cu media si deviatia date in scara originala
+	DistUniform   LambdaDist = "uniform"   // U(low, high)
+)
+
+// LambdaSpec descrie rata de sosire a unui nod: o valoare fixa (Mean, cand
+// Dist e gol) sau o distributie din care Sample extrage realizari
+type LambdaSpec struct {
+	Dist LambdaDist
+	Mean float64
+	Std  float64 // folosit de normal/lognormal
+	Low  float64 // folosit de uniform
+	High float64 // folosit de uniform
+}
+
+// UnmarshalJSON accepta fie un numar simplu (rata fixa, ca in config-urile
+// existente), fie un obiect {"dist": "...", "mean": ..., "std": ...}
+func (l *LambdaSpec) UnmarshalJSON(data []byte) error {
+	var fixed float64
+	if err := json.Unmarshal(data, &fixed); err == nil {
+		l.Dist = DistFixed
+		l.Mean = fixed
+		return nil
+	}
+
+	var spec struct {
+		Dist string  `json:"dist"`
+		Mean float64 `json:"mean"`
+		Std  float64 `json:"std"`
+		Low  float64 `json:"low"`
+		High float64 `json:"high"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+
+	l.Dist = LambdaDist(spec.Dist)
+	l.Mean = spec.Mean
+	l.Std = spec.Std
+	l.Low = spec.Low
+	l.High = spec.High
+	return nil
+}
+
+// Sample extrage o realizare a ratei de sosire; pentru DistFixed intoarce
+// mereu Mean. Rezultatul e limitat la valori nenegative (rata nu poate fi < 0)
+func (l LambdaSpec) Sample(rng *rand.Rand) float64 {
+	var value float64
+	switch l.Dist {
+	case DistNormal:
+		value = l.Mean + rng.NormFloat64()*l.Std
+	case DistLognormal:
+		// parametrii (mu, sigma) ai log-normalei astfel incat media si
+		// deviatia standard in scara originala sa fie Mean, Std
+		variance := l.Std * l.Std
+		sigma2 := math.Log(1 + variance/(l.Mean*l.Mean))
+		mu := math.Log(l.Mean) - 0.5*sigma2
+		value = math.Exp(mu + rng.NormFloat64()*math.Sqrt(sigma2))
+	case DistUniform:
+		value = l.Low + rng.Float64()*(l.High-l.Low)
+	default:
+		value = l.Mean
+	}
+	return math.Max(0, value)
+}
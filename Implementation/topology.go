@@ -0,0 +1,199 @@
+package main
+
+import "math/rand"
+
+// |===================|
+// |Topologii de retea|
+// |===================|
+// Pana acum PairwiseAlgorithm primea o lista de muchii construita manual in
+// main. Topology formalizeaza generarea acestei liste, astfel incat
+// conectivitatea retelei sa fie o optiune de configurare, nu cod cablat
+
+// Topology genereaza muchiile (legaturile) dintre noduri si stie cum se numeste,
+// pentru raportare in sumar si in ploturi
+type Topology interface {
+	Name() string
+	Edges() []Edge
+}
+
+// namedTopology e implementarea comuna pentru toate generatoarele de mai jos:
+// muchiile sunt calculate o singura data, la generare
+type namedTopology struct {
+	name  string
+	edges []Edge
+}
+
+func (t namedTopology) Name() string  { return t.name }
+func (t namedTopology) Edges() []Edge { return t.edges }
+
+// CompleteGraph leaga fiecare pereche de noduri (topologia folosita implicit pana acum)
+func CompleteGraph(n int) Topology {
+	edges := make([]Edge, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			edges = append(edges, Edge{From: i, To: j})
+		}
+	}
+	return namedTopology{name: "Complete", edges: edges}
+}
+
+// Ring leaga fiecare nod de urmatorul, ciclic
+func Ring(n int) Topology {
+	edges := make([]Edge, 0, n)
+	for i := 0; i < n; i++ {
+		edges = append(edges, Edge{From: i, To: (i + 1) % n})
+	}
+	return namedTopology{name: "Ring", edges: edges}
+}
+
+// Grid2D aranjeaza nodurile intr-o grila rows x cols, legand vecinii pe orizontala si verticala
+func Grid2D(rows, cols int) Topology {
+	index := func(r, c int) int { return r*cols + c }
+
+	edges := make([]Edge, 0, rows*cols*2)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				edges = append(edges, Edge{From: index(r, c), To: index(r, c+1)})
+			}
+			if r+1 < rows {
+				edges = append(edges, Edge{From: index(r, c), To: index(r+1, c)})
+			}
+		}
+	}
+	return namedTopology{name: "Grid2D", edges: edges}
+}
+
+// RandomRegular genereaza un graf aleator d-regulat prin metoda "configuration
+// model" (stub matching), respingand esantioanele cu auto-bucle sau muchii
+// duplicate; dupa un numar de incercari fara succes, revine la un inel
+func RandomRegular(n, d int, seed int64) Topology {
+	rng := rand.New(rand.NewSource(seed))
+
+	for attempt := 0; attempt < 100; attempt++ {
+		stubs := make([]int, 0, n*d)
+		for node := 0; node < n; node++ {
+			for k := 0; k < d; k++ {
+				stubs = append(stubs, node)
+			}
+		}
+		rng.Shuffle(len(stubs), func(i, j int) { stubs[i], stubs[j] = stubs[j], stubs[i] })
+
+		seen := make(map[Edge]bool, len(stubs)/2)
+		edges := make([]Edge, 0, len(stubs)/2)
+		valid := true
+
+		for i := 0; i+1 < len(stubs); i += 2 {
+			a, b := stubs[i], stubs[i+1]
+			if a == b {
+				valid = false
+				break
+			}
+			e := canonicalEdge(a, b)
+			if seen[e] {
+				valid = false
+				break
+			}
+			seen[e] = true
+			edges = append(edges, e)
+		}
+
+		if valid {
+			return namedTopology{name: "RandomRegular", edges: edges}
+		}
+	}
+
+	return Ring(n)
+}
+
+// BarabasiAlbert genereaza un graf prin attachment preferential: nodurile
+// noi se leaga de m noduri existente, alese cu probabilitate proportionala
+// cu gradul lor curent (nodurile cu mai multe legaturi atrag mai multe)
+func BarabasiAlbert(n, m int, seed int64) Topology {
+	rng := rand.New(rand.NewSource(seed))
+
+	edges := make([]Edge, 0, n*m)
+	// targets contine cate o aparitie per capat de muchie existent; alegerea
+	// uniforma din aceasta lista realizeaza attachment-ul preferential
+	targets := make([]int, 0, 2*n*m)
+
+	seedNodes := m
+	if seedNodes > n {
+		seedNodes = n
+	}
+	for i := 0; i < seedNodes; i++ {
+		targets = append(targets, i)
+	}
+
+	for newNode := seedNodes; newNode < n; newNode++ {
+		linksWanted := m
+		if linksWanted > newNode {
+			linksWanted = newNode
+		}
+
+		chosen := make(map[int]bool, linksWanted)
+		for len(chosen) < linksWanted {
+			candidate := targets[rng.Intn(len(targets))]
+			chosen[candidate] = true
+		}
+
+		for target := range chosen {
+			edges = append(edges, Edge{From: newNode, To: target})
+			targets = append(targets, newNode, target)
+		}
+	}
+
+	return namedTopology{name: "BarabasiAlbert", edges: edges}
+}
+
+// TopologyConfig selecteaza topologia din config.json
+type TopologyConfig struct {
+	Type string `json:"type"` // "complete" (implicit), "ring", "grid2d", "random_regular", "barabasi_albert"
+	Rows int    `json:"rows"` // folosit de grid2d
+	Cols int    `json:"cols"` // folosit de grid2d
+	D    int    `json:"d"`    // folosit de random_regular
+	M    int    `json:"m"`    // folosit de barabasi_albert
+	Seed int64  `json:"seed"` // folosit de random_regular si barabasi_albert
+}
+
+// BuildTopology construieste topologia descrisa de cfg pentru n noduri;
+// implicit (cfg.Type gol sau necunoscut) foloseste graful complet
+func BuildTopology(cfg TopologyConfig, n int) Topology {
+	switch cfg.Type {
+	case "ring":
+		return Ring(n)
+	case "grid2d":
+		// rows*cols trebuie sa acopere exact cele n noduri ale sistemului;
+		// altfel Grid2D ar genera muchii catre indici inexistenti, care ar
+		// panica mai tarziu in solver. La nepotrivire, revenim la graful complet
+		if cfg.Rows*cfg.Cols != n {
+			return CompleteGraph(n)
+		}
+		return Grid2D(cfg.Rows, cfg.Cols)
+	case "random_regular":
+		return RandomRegular(n, cfg.D, cfg.Seed)
+	case "barabasi_albert":
+		return BarabasiAlbert(n, cfg.M, cfg.Seed)
+	default:
+		return CompleteGraph(n)
+	}
+}
+
+// buildAdjacency transforma o lista de muchii intr-o lista de adiacenta (neorientata)
+func buildAdjacency(n int, edges []Edge) [][]int {
+	adjacency := make([][]int, n)
+	for _, e := range edges {
+		adjacency[e.From] = append(adjacency[e.From], e.To)
+		adjacency[e.To] = append(adjacency[e.To], e.From)
+	}
+	return adjacency
+}
+
+// canonicalEdge normalizeaza o pereche (a, b) astfel incat From <= To,
+// pentru a putea folosi Edge ca si cheie de map indiferent de ordinea nodurilor
+func canonicalEdge(a, b int) Edge {
+	if a < b {
+		return Edge{From: a, To: b}
+	}
+	return Edge{From: b, To: a}
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+// TestBuildTopologyGridMismatchFallsBack reproduce un config grid2d cu
+// rows*cols != n (ex. o grila 3x3 pentru un sistem de 4 noduri), care facea
+// Grid2D sa genereze muchii catre indici inexistenti si sa panice mai tarziu
+// in solver. BuildTopology trebuie sa cada inapoi pe graful complet
+func TestBuildTopologyGridMismatchFallsBack(t *testing.T) {
+	n := 4
+	cfg := TopologyConfig{Type: "grid2d", Rows: 3, Cols: 3}
+
+	topo := BuildTopology(cfg, n)
+
+	for _, e := range topo.Edges() {
+		if e.From < 0 || e.From >= n || e.To < 0 || e.To >= n {
+			t.Fatalf("topology edge %+v references a node outside [0, %d)", e, n)
+		}
+	}
+}